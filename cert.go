@@ -0,0 +1,42 @@
+// Package buford sends push notifications to Apple's HTTP/2 APNs service,
+// using either a provider certificate or JWT provider authentication.
+package buford
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+
+	"golang.org/x/crypto/pkcs12"
+	"golang.org/x/net/http2"
+)
+
+// LoadCert loads a PKCS#12 (.p12) provider certificate for certificate-based authentication.
+func LoadCert(filename, password string) (tls.Certificate, error) {
+	p12, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	key, leaf, err := pkcs12.Decode(p12, password)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{leaf.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
+
+// NewClient creates an *http.Client configured for HTTP/2 provider certificate authentication.
+func NewClient(cert tls.Certificate) *http.Client {
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	return &http.Client{
+		Transport: &http2.Transport{TLSClientConfig: config},
+	}
+}
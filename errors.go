@@ -0,0 +1,45 @@
+package buford
+
+import "fmt"
+
+// Error is returned by Service.Push when APNs rejects a notification. It
+// wraps the JSON "reason" Apple sends in the response body, so callers can
+// compare it against the sentinel Err* values with errors.Is.
+type Error struct {
+	// Reason is the string APNs returned, e.g. "BadDeviceToken".
+	Reason string
+
+	// StatusCode is the HTTP status APNs responded with.
+	StatusCode int
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("buford: push rejected: %s (status %d)", e.Reason, e.StatusCode)
+}
+
+// Is reports whether err is an *Error with the same Reason, so the sentinel
+// Err* values below work with errors.Is(err, buford.ErrBadDeviceToken).
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Reason == e.Reason
+}
+
+// Sentinel reasons APNs returns in its JSON error response. See
+// https://developer.apple.com/documentation/usernotifications/handling_notification_responses_from_apns
+var (
+	ErrBadDeviceToken         = &Error{Reason: "BadDeviceToken"}
+	ErrDeviceTokenNotForTopic = &Error{Reason: "DeviceTokenNotForTopic"}
+	ErrExpiredProviderToken   = &Error{Reason: "ExpiredProviderToken"}
+	ErrTooManyRequests        = &Error{Reason: "TooManyRequests"}
+	ErrPayloadTooLarge        = &Error{Reason: "PayloadTooLarge"}
+	ErrMissingTopic           = &Error{Reason: "MissingTopic"}
+	ErrBadTopic               = &Error{Reason: "BadTopic"}
+	ErrUnregistered           = &Error{Reason: "Unregistered"}
+)
+
+// IsUnregistered reports whether err means the device token is no longer
+// valid and should be removed, so callers can prune it from storage.
+func IsUnregistered(err error) bool {
+	apnsErr, ok := err.(*Error)
+	return ok && apnsErr.Reason == ErrUnregistered.Reason
+}
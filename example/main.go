@@ -4,7 +4,7 @@ import (
 	"flag"
 	"log"
 
-	"github.com/RobotsAndPencils/buford"
+	"github.com/alexei-g-aloteq/buford"
 )
 
 func main() {
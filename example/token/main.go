@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/alexei-g-aloteq/buford"
+	"github.com/alexei-g-aloteq/buford/token"
+)
+
+func main() {
+	var deviceToken, authKeyFile, keyID, teamID string
+
+	flag.StringVar(&deviceToken, "d", "", "Device token")
+	flag.StringVar(&authKeyFile, "k", "", "Path to .p8 authentication key file")
+	flag.StringVar(&keyID, "kid", "", "Key ID from the Apple Developer portal")
+	flag.StringVar(&teamID, "tid", "", "Team ID from the Apple Developer portal")
+	flag.Parse()
+
+	authKey, err := token.AuthKeyFromFile(authKeyFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tok, err := token.New(authKey, keyID, teamID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	service := buford.Service{
+		Client: buford.NewTokenClient(),
+		Host:   "https://api.sandbox.push.apple.com",
+		Token:  tok,
+	}
+
+	err = service.Push(deviceToken, []byte(`{ "aps" : { "alert" : "Hello HTTP/2" } }`))
+	if err != nil {
+		log.Fatal(err)
+	}
+}
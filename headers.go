@@ -0,0 +1,91 @@
+package buford
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// PushType selects the apns-push-type header, which tells APNs how to
+// prioritize and route the notification. iOS 13 and newer require it for
+// anything other than a visible alert, and iOS 16.1 and newer require it
+// for Live Activity updates.
+type PushType string
+
+// Push types recognized by APNs.
+const (
+	PushTypeAlert        PushType = "alert"
+	PushTypeBackground   PushType = "background"
+	PushTypeVoIP         PushType = "voip"
+	PushTypeComplication PushType = "complication"
+	PushTypeFileProvider PushType = "fileprovider"
+	PushTypeMDM          PushType = "mdm"
+	PushTypeLiveActivity PushType = "liveactivity"
+	PushTypePushToTalk   PushType = "pushtotalk"
+	PushTypeLocation     PushType = "location"
+)
+
+// Notification carries the per-push headers APNs uses to route, collapse,
+// and expire a notification. Pass it to Service.PushWithHeaders.
+type Notification struct {
+	// PushType sets the apns-push-type header.
+	PushType PushType
+
+	// Topic is usually your app's bundle ID. VoIP pushes must use the
+	// "<bundle>.voip" topic, and Live Activity pushes must use
+	// "<bundle>.push-type.liveactivity".
+	Topic string
+
+	// CollapseID replaces a pending notification with the same ID,
+	// so only the latest one is shown. Sets apns-collapse-id.
+	CollapseID string
+
+	// Priority is 10 (send immediately) or 5 (power considerate).
+	// Sets apns-priority; zero is left unset and APNs defaults to 10.
+	Priority int
+
+	// Expiration is when APNs should stop trying to deliver the notification.
+	// Sets apns-expiration; the zero value means "don't store it at all".
+	Expiration time.Time
+}
+
+// ErrTopicSuffix is returned when a VoIP or Live Activity notification's
+// Topic doesn't carry the suffix APNs requires for that push type.
+var ErrTopicSuffix = errors.New("buford: topic does not have the suffix required for this push type")
+
+// ErrMissingLiveActivityEvent is returned when a Live Activity notification's
+// payload doesn't set aps.event.
+var ErrMissingLiveActivityEvent = errors.New("buford: live activity push requires an aps.event payload field")
+
+// validate checks the header/payload combination against Apple's push-type rules.
+func (n *Notification) validate(payload []byte) error {
+	switch n.PushType {
+	case PushTypeVoIP:
+		if !strings.HasSuffix(n.Topic, ".voip") {
+			return ErrTopicSuffix
+		}
+	case PushTypeLiveActivity:
+		if !strings.HasSuffix(n.Topic, ".push-type.liveactivity") {
+			return ErrTopicSuffix
+		}
+		if !payloadHasEvent(payload) {
+			return ErrMissingLiveActivityEvent
+		}
+	}
+	return nil
+}
+
+// payloadHasEvent reports whether the serialized payload sets aps.event,
+// without requiring the caller to build it through the payload package.
+func payloadHasEvent(payload []byte) bool {
+	var body struct {
+		APS struct {
+			Event string `json:"event"`
+		} `json:"aps"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return false
+	}
+	return body.APS.Event != ""
+}
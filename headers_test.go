@@ -0,0 +1,54 @@
+package buford
+
+import "testing"
+
+func TestNotificationValidateVoIPRequiresSuffix(t *testing.T) {
+	n := &Notification{PushType: PushTypeVoIP, Topic: "com.example.app"}
+	if err := n.validate(nil); err != ErrTopicSuffix {
+		t.Errorf("validate() = %v, want ErrTopicSuffix", err)
+	}
+
+	n = &Notification{PushType: PushTypeVoIP, Topic: "com.example.app.voip"}
+	if err := n.validate(nil); err != nil {
+		t.Errorf("validate() = %v, want nil", err)
+	}
+}
+
+func TestNotificationValidateLiveActivityRequiresSuffix(t *testing.T) {
+	n := &Notification{PushType: PushTypeLiveActivity, Topic: "com.example.app"}
+	payload := []byte(`{"aps":{"event":"start"}}`)
+	if err := n.validate(payload); err != ErrTopicSuffix {
+		t.Errorf("validate() = %v, want ErrTopicSuffix", err)
+	}
+}
+
+func TestNotificationValidateLiveActivityRequiresEvent(t *testing.T) {
+	n := &Notification{PushType: PushTypeLiveActivity, Topic: "com.example.app.push-type.liveactivity"}
+
+	if err := n.validate([]byte(`{"aps":{"alert":"hi"}}`)); err != ErrMissingLiveActivityEvent {
+		t.Errorf("validate() = %v, want ErrMissingLiveActivityEvent", err)
+	}
+
+	if err := n.validate([]byte(`{"aps":{"event":"update"}}`)); err != nil {
+		t.Errorf("validate() = %v, want nil", err)
+	}
+}
+
+func TestNotificationValidateIgnoresOtherPushTypes(t *testing.T) {
+	n := &Notification{PushType: PushTypeAlert, Topic: "com.example.app"}
+	if err := n.validate(nil); err != nil {
+		t.Errorf("validate() = %v, want nil", err)
+	}
+}
+
+func TestPayloadHasEvent(t *testing.T) {
+	if payloadHasEvent([]byte(`{"aps":{"alert":"hi"}}`)) {
+		t.Error("expected no event")
+	}
+	if !payloadHasEvent([]byte(`{"aps":{"event":"end"}}`)) {
+		t.Error("expected event to be detected")
+	}
+	if payloadHasEvent([]byte(`not json`)) {
+		t.Error("expected malformed payload to report no event")
+	}
+}
@@ -2,12 +2,18 @@ package payload
 
 import (
 	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/alexei-g-aloteq/buford/payload/badge"
 )
 
 // https://developer.apple.com/documentation/usernotifications/setting_up_a_remote_notification_server/generating_a_remote_notification?language=objc
 
+// ErrIncomplete is returned by Validate when a payload doesn't have enough
+// fields set for APNs to do anything useful with it.
+var ErrIncomplete = errors.New("payload: incomplete")
+
 // APS is Apple's reserved namespace.
 // Use it for payloads destined to mobile devices (iOS).
 type APS struct {
@@ -44,6 +50,33 @@ type APS struct {
 	InterruptionLevel InterruptionLevel
 
 	RelevanceScore float32
+
+	// Event is set to "start", "update", or "end" for a Live Activity
+	// push introduced in iOS 16.1. Leave empty for a regular notification.
+	Event string
+
+	// ContentState is the Live Activity's updated dynamic content,
+	// required for "update" and "end" events.
+	ContentState map[string]interface{}
+
+	// AttributesType names the Live Activity's ActivityAttributes type,
+	// required to "start" one.
+	AttributesType string
+
+	// Attributes is the Live Activity's static content, required to "start" one.
+	Attributes map[string]interface{}
+
+	// StaleDate is when the system should consider the Live Activity's
+	// content out of date, as a Unix timestamp.
+	StaleDate int64
+
+	// DismissalDate is when the system should remove an ended Live
+	// Activity from the Lock Screen, as a Unix timestamp.
+	DismissalDate int64
+
+	// Timestamp records when this Live Activity update was generated.
+	// Defaults to time.Now() when Event is set and this is left zero.
+	Timestamp int64
 }
 
 type InterruptionLevel string
@@ -80,6 +113,14 @@ type Alert struct {
 
 	// String for "View" button on Safari.
 	SafariAction string `json:"action,omitempty"`
+
+	// SummaryArg localizes the summary string shown for a group of
+	// notifications sharing a ThreadID, iOS 12 or newer.
+	SummaryArg string `json:"summary-arg,omitempty"`
+
+	// SummaryArgCount is the number of items this notification represents
+	// in the group's summary, iOS 12 or newer.
+	SummaryArgCount int `json:"summary-arg-count,omitempty"`
 }
 
 // Sound dictionary.
@@ -94,7 +135,8 @@ func (a *Alert) isSimple() bool {
 	return len(a.Title) == 0 && len(a.Subtitle) == 0 &&
 		len(a.LaunchImage) == 0 &&
 		len(a.TitleLocKey) == 0 && len(a.TitleLocArgs) == 0 &&
-		len(a.LocKey) == 0 && len(a.LocArgs) == 0 && len(a.ActionLocKey) == 0
+		len(a.LocKey) == 0 && len(a.LocArgs) == 0 && len(a.ActionLocKey) == 0 &&
+		len(a.SummaryArg) == 0 && a.SummaryArgCount == 0
 }
 
 // isZero if no Alert fields are set.
@@ -144,6 +186,31 @@ func (a *APS) Map() map[string]interface{} {
 	if a.RelevanceScore > 0 {
 		aps["relevance-score"] = a.RelevanceScore
 	}
+	if a.Event != "" {
+		aps["event"] = a.Event
+
+		timestamp := a.Timestamp
+		if timestamp == 0 {
+			timestamp = time.Now().Unix()
+		}
+		aps["timestamp"] = timestamp
+
+		if a.ContentState != nil {
+			aps["content-state"] = a.ContentState
+		}
+		if a.AttributesType != "" {
+			aps["attributes-type"] = a.AttributesType
+		}
+		if a.Attributes != nil {
+			aps["attributes"] = a.Attributes
+		}
+		if a.StaleDate != 0 {
+			aps["stale-date"] = a.StaleDate
+		}
+		if a.DismissalDate != 0 {
+			aps["dismissal-date"] = a.DismissalDate
+		}
+	}
 
 	// wrap in "aps" to form the final payload
 	return map[string]interface{}{"aps": aps}
@@ -160,6 +227,28 @@ func (a *APS) Validate() error {
 		return ErrIncomplete
 	}
 
+	if a.Event != "" {
+		switch a.Event {
+		case "start":
+			if a.AttributesType == "" || a.Attributes == nil {
+				return ErrIncomplete
+			}
+		case "update", "end":
+			if a.ContentState == nil {
+				return ErrIncomplete
+			}
+		default:
+			return ErrIncomplete
+		}
+
+		// a Live Activity update is its own push; it can't also carry a
+		// regular alert or badge unless it's a silent content-available push.
+		if !a.ContentAvailable && (!a.Alert.isZero() || a.Badge != badge.Preserve) {
+			return ErrIncomplete
+		}
+		return nil
+	}
+
 	// must have a body or a badge (or custom data)
 	if len(a.Alert.Body) == 0 && a.Badge == badge.Preserve {
 		return ErrIncomplete
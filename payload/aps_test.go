@@ -0,0 +1,143 @@
+package payload
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGroupedNotificationRoundTrip(t *testing.T) {
+	aps := &APS{
+		Alert: Alert{
+			SummaryArg:      "Alice",
+			SummaryArgCount: 3,
+		},
+		ThreadID: "room1",
+	}
+
+	data, err := json.Marshal(aps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out struct {
+		APS struct {
+			Alert struct {
+				SummaryArg      string `json:"summary-arg"`
+				SummaryArgCount int    `json:"summary-arg-count"`
+			} `json:"alert"`
+			ThreadID string `json:"thread-id"`
+		} `json:"aps"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.APS.Alert.SummaryArg != "Alice" {
+		t.Errorf("summary-arg = %q, want %q", out.APS.Alert.SummaryArg, "Alice")
+	}
+	if out.APS.Alert.SummaryArgCount != 3 {
+		t.Errorf("summary-arg-count = %d, want 3", out.APS.Alert.SummaryArgCount)
+	}
+	if out.APS.ThreadID != "room1" {
+		t.Errorf("thread-id = %q, want %q", out.APS.ThreadID, "room1")
+	}
+}
+
+func TestSummaryArgDisqualifiesSimpleAlert(t *testing.T) {
+	aps := &APS{Alert: Alert{Body: "hi", SummaryArg: "Alice"}}
+
+	data, err := json.Marshal(aps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out struct {
+		APS struct {
+			Alert json.RawMessage `json:"alert"`
+		} `json:"aps"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	// a summary arg forces the full alert dictionary, not the simple string form
+	if len(out.APS.Alert) == 0 || out.APS.Alert[0] != '{' {
+		t.Errorf("alert = %s, want a JSON object", out.APS.Alert)
+	}
+}
+
+func TestLiveActivityMapFields(t *testing.T) {
+	aps := &APS{
+		Event:          "start",
+		AttributesType: "TripAttributes",
+		Attributes:     map[string]interface{}{"driver": "Alice"},
+		ContentState:   map[string]interface{}{"eta": 5},
+		Timestamp:      1700000000,
+	}
+
+	m := aps.Map()["aps"].(map[string]interface{})
+
+	if m["event"] != "start" {
+		t.Errorf("event = %v, want %q", m["event"], "start")
+	}
+	if m["attributes-type"] != "TripAttributes" {
+		t.Errorf("attributes-type = %v, want %q", m["attributes-type"], "TripAttributes")
+	}
+	if m["timestamp"] != int64(1700000000) {
+		t.Errorf("timestamp = %v, want explicit Timestamp to be used as-is", m["timestamp"])
+	}
+	if _, ok := m["content-state"]; !ok {
+		t.Error("expected content-state to be set")
+	}
+}
+
+func TestLiveActivityValidateStartRequiresAttributes(t *testing.T) {
+	aps := &APS{Event: "start"}
+	if err := aps.Validate(); err != ErrIncomplete {
+		t.Errorf("Validate() = %v, want ErrIncomplete", err)
+	}
+
+	aps = &APS{
+		Event:          "start",
+		AttributesType: "TripAttributes",
+		Attributes:     map[string]interface{}{"driver": "Alice"},
+	}
+	if err := aps.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestLiveActivityValidateUpdateRequiresContentState(t *testing.T) {
+	aps := &APS{Event: "update"}
+	if err := aps.Validate(); err != ErrIncomplete {
+		t.Errorf("Validate() = %v, want ErrIncomplete", err)
+	}
+
+	aps = &APS{Event: "update", ContentState: map[string]interface{}{"eta": 5}}
+	if err := aps.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestLiveActivityValidateRejectsUnknownEvent(t *testing.T) {
+	aps := &APS{
+		Event:          "updat", // typo
+		AttributesType: "TripAttributes",
+		Attributes:     map[string]interface{}{"driver": "Alice"},
+		ContentState:   map[string]interface{}{"eta": 5},
+	}
+	if err := aps.Validate(); err != ErrIncomplete {
+		t.Errorf("Validate() = %v, want ErrIncomplete for unrecognized event", err)
+	}
+}
+
+func TestLiveActivityValidateRejectsAlertAlongsideEvent(t *testing.T) {
+	aps := &APS{
+		Event:        "update",
+		ContentState: map[string]interface{}{"eta": 5},
+		Alert:        Alert{Body: "still here"},
+	}
+	if err := aps.Validate(); err != ErrIncomplete {
+		t.Errorf("Validate() = %v, want ErrIncomplete when Alert is set alongside a Live Activity event", err)
+	}
+}
@@ -0,0 +1,160 @@
+package payload
+
+import "encoding/json"
+
+// Builder provides a chainable API for constructing a push payload.
+// Unlike APS, a Builder lets you interleave standard aps keys with
+// arbitrary custom top-level keys, since APS.MarshalJSON always wraps
+// everything under "aps".
+//
+//	payload.NewBuilder().
+//		Alert("hi").
+//		Badge(3).
+//		Sound("ping.aiff").
+//		Category("MESSAGE").
+//		ThreadID("room1").
+//		MutableContent().
+//		ContentAvailable().
+//		Custom("order_id", 42).
+//		Build()
+type Builder struct {
+	aps    map[string]interface{}
+	custom map[string]interface{}
+}
+
+// NewBuilder creates an empty Builder.
+func NewBuilder() *Builder {
+	return &Builder{
+		aps:    make(map[string]interface{}),
+		custom: make(map[string]interface{}),
+	}
+}
+
+// Alert sets a simple alert body. If Localize or SummaryArg already built
+// an alert dictionary, body joins it instead of replacing it.
+func (b *Builder) Alert(body string) *Builder {
+	if alert, ok := b.aps["alert"].(map[string]interface{}); ok {
+		alert["body"] = body
+		return b
+	}
+	b.aps["alert"] = body
+	return b
+}
+
+// Localize sets an alert looked up by key, formatted with args, for iOS 8 or newer.
+func (b *Builder) Localize(key string, args ...string) *Builder {
+	alert := b.alertMap()
+	alert["loc-key"] = key
+	if len(args) > 0 {
+		alert["loc-args"] = args
+	}
+	return b
+}
+
+// SummaryArg sets the iOS 12 summary-arg and summary-arg-count used to
+// localize and count notifications grouped into the same thread.
+func (b *Builder) SummaryArg(arg string, count int) *Builder {
+	alert := b.alertMap()
+	alert["summary-arg"] = arg
+	alert["summary-arg-count"] = count
+	return b
+}
+
+// Badge sets the number to display on the app icon.
+func (b *Builder) Badge(n int) *Builder {
+	b.aps["badge"] = n
+	return b
+}
+
+// Sound names a sound file, bundled with the app, to play.
+func (b *Builder) Sound(name string) *Builder {
+	b.aps["sound"] = name
+	return b
+}
+
+// CriticalSound plays name even when the device is muted or in Do Not
+// Disturb, at volume (0.0 to 1.0). Requires the critical alerts entitlement.
+func (b *Builder) CriticalSound(name string, volume float32) *Builder {
+	b.aps["sound"] = Sound{
+		SoundName:      name,
+		IsCritical:     1,
+		CriticalVolume: volume,
+	}
+	return b
+}
+
+// Category names the custom actions to show with the notification, iOS 8 or newer.
+func (b *Builder) Category(category string) *Builder {
+	b.aps["category"] = category
+	return b
+}
+
+// ThreadID groups this notification with others sharing the same id, iOS 12 or newer.
+func (b *Builder) ThreadID(id string) *Builder {
+	b.aps["thread-id"] = id
+	return b
+}
+
+// MutableContent lets a Notification Service Extension modify the payload
+// before it's displayed, iOS 10 or newer.
+func (b *Builder) MutableContent() *Builder {
+	b.aps["mutable-content"] = 1
+	return b
+}
+
+// ContentAvailable marks this as a silent notification with no alert, sound, or badge.
+func (b *Builder) ContentAvailable() *Builder {
+	b.aps["content-available"] = 1
+	return b
+}
+
+// Custom sets an arbitrary top-level key alongside aps, for data your app
+// reads directly from the notification payload.
+func (b *Builder) Custom(key string, value interface{}) *Builder {
+	b.custom[key] = value
+	return b
+}
+
+// alertMap returns the alert dictionary, converting a simple string alert
+// already set into its "body" form so localization/grouping keys can join it.
+func (b *Builder) alertMap() map[string]interface{} {
+	switch alert := b.aps["alert"].(type) {
+	case map[string]interface{}:
+		return alert
+	case string:
+		m := map[string]interface{}{"body": alert}
+		b.aps["alert"] = m
+		return m
+	default:
+		m := make(map[string]interface{})
+		b.aps["alert"] = m
+		return m
+	}
+}
+
+// Map returns the payload as a map that you can further customize before serializing it to JSON.
+func (b *Builder) Map() map[string]interface{} {
+	payload := make(map[string]interface{}, len(b.custom)+1)
+	for k, v := range b.custom {
+		payload[k] = v
+	}
+	payload["aps"] = b.aps
+	return payload
+}
+
+// MarshalJSON allows you to json.Marshal(builder) directly.
+func (b *Builder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Map())
+}
+
+// Bytes serializes the payload to JSON.
+func (b *Builder) Bytes() ([]byte, error) {
+	return json.Marshal(b.Map())
+}
+
+// Build serializes the payload to JSON, discarding any marshaling error.
+// Use Bytes instead if you need to handle that error.
+func (b *Builder) Build() []byte {
+	data, _ := b.Bytes()
+	return data
+}
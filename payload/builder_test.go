@@ -0,0 +1,193 @@
+package payload
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBuilderMapInterleavesCustomKeys(t *testing.T) {
+	data := NewBuilder().
+		Alert("hi").
+		Badge(3).
+		Sound("ping.aiff").
+		Category("MESSAGE").
+		ThreadID("room1").
+		MutableContent().
+		ContentAvailable().
+		Custom("order_id", 42).
+		Build()
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out["order_id"] != float64(42) {
+		t.Errorf("order_id = %v, want 42", out["order_id"])
+	}
+
+	aps, ok := out["aps"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected an aps dictionary")
+	}
+	if aps["alert"] != "hi" {
+		t.Errorf("alert = %v, want %q", aps["alert"], "hi")
+	}
+	if aps["badge"] != float64(3) {
+		t.Errorf("badge = %v, want 3", aps["badge"])
+	}
+	if aps["category"] != "MESSAGE" {
+		t.Errorf("category = %v, want %q", aps["category"], "MESSAGE")
+	}
+	if aps["thread-id"] != "room1" {
+		t.Errorf("thread-id = %v, want %q", aps["thread-id"], "room1")
+	}
+	if aps["mutable-content"] != float64(1) {
+		t.Errorf("mutable-content = %v, want 1", aps["mutable-content"])
+	}
+	if aps["content-available"] != float64(1) {
+		t.Errorf("content-available = %v, want 1", aps["content-available"])
+	}
+}
+
+func TestBuilderLocalizeAfterAlertKeepsBody(t *testing.T) {
+	data := NewBuilder().Alert("hi").Localize("GREETING_KEY", "Alice").Build()
+
+	var out struct {
+		APS struct {
+			Alert struct {
+				Body    string   `json:"body"`
+				LocKey  string   `json:"loc-key"`
+				LocArgs []string `json:"loc-args"`
+			} `json:"alert"`
+		} `json:"aps"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.APS.Alert.Body != "hi" {
+		t.Errorf("body = %q, want %q", out.APS.Alert.Body, "hi")
+	}
+	if out.APS.Alert.LocKey != "GREETING_KEY" {
+		t.Errorf("loc-key = %q, want %q", out.APS.Alert.LocKey, "GREETING_KEY")
+	}
+	if len(out.APS.Alert.LocArgs) != 1 || out.APS.Alert.LocArgs[0] != "Alice" {
+		t.Errorf("loc-args = %v, want [Alice]", out.APS.Alert.LocArgs)
+	}
+}
+
+func TestBuilderAlertAfterLocalizeJoinsExistingMap(t *testing.T) {
+	data := NewBuilder().Localize("GREETING_KEY", "Alice").Alert("hi").Build()
+
+	var out struct {
+		APS struct {
+			Alert struct {
+				Body    string   `json:"body"`
+				LocKey  string   `json:"loc-key"`
+				LocArgs []string `json:"loc-args"`
+			} `json:"alert"`
+		} `json:"aps"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.APS.Alert.Body != "hi" {
+		t.Errorf("body = %q, want %q", out.APS.Alert.Body, "hi")
+	}
+	if out.APS.Alert.LocKey != "GREETING_KEY" {
+		t.Errorf("loc-key = %q, want %q, Alert() must not clobber it", out.APS.Alert.LocKey, "GREETING_KEY")
+	}
+}
+
+func TestBuilderAlertAfterSummaryArgJoinsExistingMap(t *testing.T) {
+	data := NewBuilder().ThreadID("room1").SummaryArg("Alice", 3).Alert("hi").Build()
+
+	var out struct {
+		APS struct {
+			Alert struct {
+				Body            string `json:"body"`
+				SummaryArg      string `json:"summary-arg"`
+				SummaryArgCount int    `json:"summary-arg-count"`
+			} `json:"alert"`
+		} `json:"aps"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.APS.Alert.Body != "hi" {
+		t.Errorf("body = %q, want %q", out.APS.Alert.Body, "hi")
+	}
+	if out.APS.Alert.SummaryArg != "Alice" {
+		t.Errorf("summary-arg = %q, want %q, Alert() must not clobber it", out.APS.Alert.SummaryArg, "Alice")
+	}
+	if out.APS.Alert.SummaryArgCount != 3 {
+		t.Errorf("summary-arg-count = %d, want 3", out.APS.Alert.SummaryArgCount)
+	}
+}
+
+func TestBuilderSummaryArg(t *testing.T) {
+	data := NewBuilder().Alert("hi").ThreadID("room1").SummaryArg("Alice", 3).Build()
+
+	var out struct {
+		APS struct {
+			Alert struct {
+				SummaryArg      string `json:"summary-arg"`
+				SummaryArgCount int    `json:"summary-arg-count"`
+			} `json:"alert"`
+		} `json:"aps"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.APS.Alert.SummaryArg != "Alice" {
+		t.Errorf("summary-arg = %q, want %q", out.APS.Alert.SummaryArg, "Alice")
+	}
+	if out.APS.Alert.SummaryArgCount != 3 {
+		t.Errorf("summary-arg-count = %d, want 3", out.APS.Alert.SummaryArgCount)
+	}
+}
+
+func TestBuilderCriticalSound(t *testing.T) {
+	data := NewBuilder().CriticalSound("alarm.aiff", 1.0).Build()
+
+	var out struct {
+		APS struct {
+			Sound Sound `json:"sound"`
+		} `json:"aps"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.APS.Sound.SoundName != "alarm.aiff" {
+		t.Errorf("sound name = %q, want %q", out.APS.Sound.SoundName, "alarm.aiff")
+	}
+	if out.APS.Sound.IsCritical != 1 {
+		t.Errorf("critical = %d, want 1", out.APS.Sound.IsCritical)
+	}
+	if out.APS.Sound.CriticalVolume != 1.0 {
+		t.Errorf("volume = %v, want 1.0", out.APS.Sound.CriticalVolume)
+	}
+}
+
+func TestBuilderBytesAndMarshalJSONAgree(t *testing.T) {
+	b := NewBuilder().Alert("hi")
+
+	bytesOut, err := b.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marshaled, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(bytesOut) != string(marshaled) {
+		t.Errorf("Bytes() = %s, MarshalJSON() = %s, want equal", bytesOut, marshaled)
+	}
+}
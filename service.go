@@ -0,0 +1,173 @@
+package buford
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"github.com/alexei-g-aloteq/buford/token"
+)
+
+// NewTokenClient creates an *http.Client configured for HTTP/2 JWT provider
+// authentication. It does not present a client certificate, and it does not
+// authenticate requests by itself: set Service.Token to the same *token.Token
+// you'll sign requests with, and Service.Push attaches the bearer header.
+func NewTokenClient() *http.Client {
+	return &http.Client{
+		Transport: &http2.Transport{},
+	}
+}
+
+// Service is the endpoint for sending notifications to the APNs HTTP/2 API.
+//
+// Authenticate with either a provider certificate (pass a Client from
+// NewClient) or JWT provider authentication (pass a Client from
+// NewTokenClient and set Token; Push signs and attaches the bearer header).
+type Service struct {
+	Client *http.Client
+	Host   string
+
+	// Token is used for JWT provider authentication. Leave nil when Client
+	// was created with NewClient, since the client certificate already
+	// authenticates the connection.
+	Token *token.Token
+}
+
+// Response describes APNs' reply to a push. A rejected push gets a Response
+// too; check the returned error for the rejection reason.
+type Response struct {
+	// APNsID is the apns-id APNs assigned to the notification, echoed back
+	// from the request or generated by APNs if the request didn't set one.
+	APNsID string
+
+	// StatusCode is the HTTP status APNs responded with.
+	StatusCode int
+
+	// Reason is the JSON "reason" APNs sent when StatusCode isn't 200.
+	Reason string
+
+	// Timestamp is when the device token became invalid, parsed from a 410
+	// response. Zero unless StatusCode is 410.
+	Timestamp time.Time
+}
+
+// Push sends a notification payload to a specific device.
+func (s *Service) Push(deviceToken string, payload []byte) error {
+	_, err := s.PushWithResponse(deviceToken, nil, payload)
+	return err
+}
+
+// PushWithHeaders sends a notification payload to a specific device, setting
+// the apns-push-type, apns-topic, apns-collapse-id, apns-priority, and
+// apns-expiration headers from n.
+func (s *Service) PushWithHeaders(deviceToken string, n *Notification, payload []byte) error {
+	_, err := s.PushWithResponse(deviceToken, n, payload)
+	return err
+}
+
+// PushWithResponse sends a notification payload to a specific device and
+// returns Apple's parsed response. n may be nil to omit the apns-* headers.
+//
+// A non-nil error from APNs (as opposed to a network or request-building
+// error) is always a *Error, so callers can compare it with errors.Is
+// against the sentinel Err* values, or call IsUnregistered to prune a
+// device token that's no longer valid.
+func (s *Service) PushWithResponse(deviceToken string, n *Notification, payload []byte) (*Response, error) {
+	if n != nil {
+		if err := n.validate(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := s.newRequest(deviceToken, payload)
+	if err != nil {
+		return nil, err
+	}
+	if n != nil {
+		setHeaders(req, n)
+	}
+
+	return s.do(req)
+}
+
+// newRequest builds the POST request shared by Push and PushWithHeaders,
+// attaching JWT provider authentication when s.Token is set.
+func (s *Service) newRequest(deviceToken string, payload []byte) (*http.Request, error) {
+	url := s.Host + "/3/device/" + deviceToken
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Token != nil {
+		bearer, err := s.Token.Bearer()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("authorization", "bearer "+bearer)
+	}
+
+	return req, nil
+}
+
+// setHeaders sets the apns-* headers APNs uses to route, collapse, and
+// expire a notification.
+func setHeaders(req *http.Request, n *Notification) {
+	if n.PushType != "" {
+		req.Header.Set("apns-push-type", string(n.PushType))
+	}
+	if n.Topic != "" {
+		req.Header.Set("apns-topic", n.Topic)
+	}
+	if n.CollapseID != "" {
+		req.Header.Set("apns-collapse-id", n.CollapseID)
+	}
+	if n.Priority != 0 {
+		req.Header.Set("apns-priority", strconv.Itoa(n.Priority))
+	}
+	if !n.Expiration.IsZero() {
+		req.Header.Set("apns-expiration", strconv.FormatInt(n.Expiration.Unix(), 10))
+	}
+}
+
+// apnsErrorBody is Apple's JSON error response body.
+type apnsErrorBody struct {
+	Reason    string `json:"reason"`
+	Timestamp int64  `json:"timestamp"` // milliseconds since the epoch, 410 only
+}
+
+// do sends req and parses Apple's response, translating a non-200 status
+// into a *Error.
+func (s *Service) do(req *http.Request) (*Response, error) {
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &Response{
+		APNsID:     resp.Header.Get("apns-id"),
+		StatusCode: resp.StatusCode,
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return response, nil
+	}
+
+	var body apnsErrorBody
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return response, &Error{Reason: resp.Status, StatusCode: resp.StatusCode}
+	}
+	response.Reason = body.Reason
+
+	if resp.StatusCode == http.StatusGone && body.Timestamp != 0 {
+		response.Timestamp = time.UnixMilli(body.Timestamp)
+	}
+
+	return response, &Error{Reason: body.Reason, StatusCode: resp.StatusCode}
+}
@@ -0,0 +1,105 @@
+package buford
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorIsMatchesSentinelByReason(t *testing.T) {
+	err := &Error{Reason: "BadDeviceToken", StatusCode: 400}
+	if !errors.Is(err, ErrBadDeviceToken) {
+		t.Error("expected errors.Is to match ErrBadDeviceToken by Reason")
+	}
+	if errors.Is(err, ErrExpiredProviderToken) {
+		t.Error("expected errors.Is not to match a different Reason")
+	}
+}
+
+func TestIsUnregistered(t *testing.T) {
+	if !IsUnregistered(&Error{Reason: "Unregistered"}) {
+		t.Error("expected IsUnregistered to recognize the Unregistered reason")
+	}
+	if IsUnregistered(&Error{Reason: "BadDeviceToken"}) {
+		t.Error("expected IsUnregistered to reject other reasons")
+	}
+	if IsUnregistered(errors.New("not an *Error")) {
+		t.Error("expected IsUnregistered to reject non-*Error values")
+	}
+}
+
+func TestPushWithResponseParsesSuccess(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("apns-id", "abc-123")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	s := &Service{Client: ts.Client(), Host: ts.URL}
+	resp, err := s.PushWithResponse("token", nil, []byte(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.APNsID != "abc-123" {
+		t.Errorf("APNsID = %q, want %q", resp.APNsID, "abc-123")
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestPushWithResponseParsesRejection(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+	}))
+	defer ts.Close()
+
+	s := &Service{Client: ts.Client(), Host: ts.URL}
+	resp, err := s.PushWithResponse("token", nil, []byte(`{}`))
+	if !errors.Is(err, ErrBadDeviceToken) {
+		t.Errorf("err = %v, want ErrBadDeviceToken", err)
+	}
+	if resp.Reason != "BadDeviceToken" {
+		t.Errorf("Reason = %q, want %q", resp.Reason, "BadDeviceToken")
+	}
+}
+
+func TestPushWithResponseParsesUnregisteredTimestamp(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+		w.Write([]byte(`{"reason":"Unregistered","timestamp":1700000000000}`))
+	}))
+	defer ts.Close()
+
+	s := &Service{Client: ts.Client(), Host: ts.URL}
+	resp, err := s.PushWithResponse("token", nil, []byte(`{}`))
+	if !IsUnregistered(err) {
+		t.Errorf("err = %v, want Unregistered", err)
+	}
+	if resp.Timestamp.Unix() != 1700000000 {
+		t.Errorf("Timestamp = %v, want unix 1700000000", resp.Timestamp)
+	}
+}
+
+func TestPushWithResponseHandlesNonJSONErrorBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`<html>bad gateway</html>`))
+	}))
+	defer ts.Close()
+
+	s := &Service{Client: ts.Client(), Host: ts.URL}
+	_, err := s.PushWithResponse("token", nil, []byte(`{}`))
+	if err == nil {
+		t.Fatal("expected an error for a non-200 response")
+	}
+	apnsErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("err = %T, want *Error", err)
+	}
+	if apnsErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("StatusCode = %d, want 502", apnsErr.StatusCode)
+	}
+}
@@ -0,0 +1,112 @@
+// Package token signs and caches JSON Web Tokens for APNs provider authentication,
+// as an alternative to connecting with a provider certificate.
+// See https://developer.apple.com/documentation/usernotifications/establishing_a_token-based_connection_to_apns
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// timeout is how old a signed token may get before it is regenerated.
+// Apple honors a token for one hour, so this keeps it inside that window.
+const timeout = 55 * time.Minute
+
+// Token signs and caches a bearer token for APNs provider authentication.
+// Generate a new one at startup and share it between Services; it refreshes itself.
+type Token struct {
+	// AuthKey is the private key from Apple's .p8 file.
+	AuthKey *ecdsa.PrivateKey
+
+	// KeyID is the 10-character key identifier from the Apple Developer portal.
+	KeyID string
+
+	// TeamID is the 10-character Team ID from the Apple Developer portal.
+	TeamID string
+
+	mu       sync.Mutex
+	bearer   string
+	issuedAt time.Time
+}
+
+// AuthKeyFromFile loads a .p8 PKCS#8 ECDSA (P-256) private key from a file on disk.
+func AuthKeyFromFile(filename string) (*ecdsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return AuthKeyFromBytes(data)
+}
+
+// AuthKeyFromBytes loads a .p8 PKCS#8 ECDSA (P-256) private key from memory.
+func AuthKeyFromBytes(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("token: no PEM data found in auth key")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	authKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("token: auth key is not an ECDSA private key")
+	}
+
+	return authKey, nil
+}
+
+// New creates a Token and signs its first bearer value.
+func New(authKey *ecdsa.PrivateKey, keyID, teamID string) (*Token, error) {
+	t := &Token{
+		AuthKey: authKey,
+		KeyID:   keyID,
+		TeamID:  teamID,
+	}
+	if err := t.generate(time.Now()); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Bearer returns the signed JWT to use as the authorization header,
+// refreshing it first once it's timeout (55 minutes) old, before Apple's
+// one-hour limit is reached.
+func (t *Token) Bearer() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if time.Since(t.issuedAt) >= timeout {
+		if err := t.generate(time.Now()); err != nil {
+			return "", err
+		}
+	}
+	return t.bearer, nil
+}
+
+// generate signs a fresh token and caches it. Callers must hold t.mu.
+func (t *Token) generate(now time.Time) error {
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": t.TeamID,
+		"iat": now.Unix(),
+	})
+	jwtToken.Header["kid"] = t.KeyID
+
+	bearer, err := jwtToken.SignedString(t.AuthKey)
+	if err != nil {
+		return err
+	}
+
+	t.bearer = bearer
+	t.issuedAt = now
+	return nil
+}
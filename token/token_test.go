@@ -0,0 +1,81 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+)
+
+// stubKey stands in for a loaded .p8 auth key so tests don't depend on a fixture file.
+func stubKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestNewSignsImmediately(t *testing.T) {
+	tok, err := New(stubKey(t), "KEYID12345", "TEAMID1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bearer, err := tok.Bearer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bearer == "" {
+		t.Fatal("expected a signed bearer token")
+	}
+}
+
+func TestBearerReusesUnexpiredToken(t *testing.T) {
+	tok, err := New(stubKey(t), "KEYID12345", "TEAMID1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := tok.Bearer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := tok.Bearer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Error("expected Bearer to reuse the cached token when still fresh")
+	}
+}
+
+func TestBearerRefreshesAfterTimeout(t *testing.T) {
+	tok, err := New(stubKey(t), "KEYID12345", "TEAMID1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := tok.Bearer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// force the cached token to look older than Apple's ~1 hour limit
+	tok.mu.Lock()
+	tok.issuedAt = time.Now().Add(-2 * timeout)
+	tok.mu.Unlock()
+
+	second, err := tok.Bearer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first == second {
+		t.Error("expected Bearer to refresh an expired token")
+	}
+}